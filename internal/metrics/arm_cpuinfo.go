@@ -0,0 +1,112 @@
+package metrics
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"runtime"
+	"strconv"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// armImplementers maps the "CPU implementer" hex code found in
+// /proc/cpuinfo to the vendor name it identifies, mirroring the table
+// containerd's platforms/cpuinfo.go uses to make sense of the same field.
+var armImplementers = map[string]string{
+	"0x41": "ARM",
+	"0x42": "Broadcom",
+	"0x43": "Cavium",
+	"0x44": "DEC",
+	"0x4e": "Nvidia",
+	"0x50": "APM",
+	"0x51": "Qualcomm",
+	"0x53": "Samsung",
+	"0x56": "Marvell",
+	"0x61": "Apple",
+	"0x66": "Faraday",
+	"0x69": "Intel",
+	"0xc0": "Ampere",
+}
+
+// populateArmCPUInfo fills in the fields lscpu can't reliably provide on ARM
+// (Variant, and on some aarch64 systems Vendor and Model) by parsing
+// /proc/cpuinfo directly. It's a no-op on non-ARM architectures, and leaves
+// c untouched if /proc/cpuinfo can't be read (e.g. non-Linux).
+func populateArmCPUInfo(c *cpuInfo) {
+	if runtime.GOARCH != "arm" && runtime.GOARCH != "arm64" {
+		return
+	}
+
+	f, err := os.Open("/proc/cpuinfo")
+	if err != nil {
+		log.Infof("couldn't open /proc/cpuinfo to look up ARM CPU variant: %v", err)
+		return
+	}
+	defer f.Close()
+
+	if err := parseArmCPUInfo(f, c); err != nil {
+		log.Infof("couldn't read /proc/cpuinfo to look up ARM CPU variant: %v", err)
+	}
+}
+
+// parseArmCPUInfo scans /proc/cpuinfo-formatted content for the "CPU
+// architecture", "CPU implementer" and "CPU part" fields and uses them to
+// fill in c.Variant and, when lscpu left them blank, c.Vendor and c.Model.
+func parseArmCPUInfo(r io.Reader, c *cpuInfo) error {
+	var implementer, part string
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := scanner.Text()
+		i := strings.Index(line, ":")
+		if i < 0 {
+			continue
+		}
+		field := strings.TrimSpace(line[:i])
+		value := strings.TrimSpace(line[i+1:])
+
+		switch field {
+		case "CPU architecture":
+			c.Variant = armVariant(value)
+		case "CPU implementer":
+			implementer = value
+		case "CPU part":
+			part = value
+		}
+	}
+
+	if c.Vendor == "" && implementer != "" {
+		if name, ok := armImplementers[strings.ToLower(implementer)]; ok {
+			c.Vendor = name
+		}
+	}
+	if c.Model == "" {
+		c.Model = part
+	}
+
+	return scanner.Err()
+}
+
+// armVariant converts the decimal "CPU architecture" field of /proc/cpuinfo
+// into the vX label used for Ubuntu image selection and telemetry.
+func armVariant(archField string) string {
+	v, err := strconv.Atoi(archField)
+	if err != nil {
+		return ""
+	}
+
+	switch {
+	case v >= 8:
+		return "v8"
+	case v == 7:
+		return "v7"
+	case v == 6:
+		return "v6"
+	case v == 5:
+		return "v5"
+	default:
+		return ""
+	}
+}
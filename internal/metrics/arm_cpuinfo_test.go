@@ -0,0 +1,80 @@
+package metrics
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestArmVariant(t *testing.T) {
+	tests := []struct {
+		archField string
+		want      string
+	}{
+		{"8", "v8"},
+		{"9", "v8"},
+		{"7", "v7"},
+		{"6", "v6"},
+		{"5", "v5"},
+		{"4", ""},
+		{"", ""},
+		{"not-a-number", ""},
+	}
+
+	for _, tc := range tests {
+		if got := armVariant(tc.archField); got != tc.want {
+			t.Errorf("armVariant(%q) = %q, want %q", tc.archField, got, tc.want)
+		}
+	}
+}
+
+func TestParseArmCPUInfo(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		before  cpuInfo
+		want    cpuInfo
+	}{
+		{
+			name: "raspberry pi 4, aarch64 lscpu left vendor/model blank",
+			content: "processor\t: 0\n" +
+				"CPU implementer\t: 0x41\n" +
+				"CPU architecture: 8\n" +
+				"CPU variant\t: 0x0\n" +
+				"CPU part\t: 0xd08\n",
+			before: cpuInfo{},
+			want:   cpuInfo{Vendor: "ARM", Model: "0xd08", Variant: "v8"},
+		},
+		{
+			name: "lscpu already populated Vendor/Model, don't overwrite",
+			content: "CPU implementer\t: 0x4e\n" +
+				"CPU architecture: 8\n" +
+				"CPU part\t: 0x004\n",
+			before: cpuInfo{Vendor: "Nvidia Corporation", Model: "Carmel"},
+			want:   cpuInfo{Vendor: "Nvidia Corporation", Model: "Carmel", Variant: "v8"},
+		},
+		{
+			name:    "unknown implementer",
+			content: "CPU implementer\t: 0xff\nCPU architecture: 7\n",
+			before:  cpuInfo{},
+			want:    cpuInfo{Variant: "v7"},
+		},
+		{
+			name:    "no arm fields present",
+			content: "processor\t: 0\nmodel name\t: something\n",
+			before:  cpuInfo{},
+			want:    cpuInfo{},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			c := tc.before
+			if err := parseArmCPUInfo(strings.NewReader(tc.content), &c); err != nil {
+				t.Fatalf("parseArmCPUInfo returned an error: %v", err)
+			}
+			if c != tc.want {
+				t.Errorf("parseArmCPUInfo() = %+v, want %+v", c, tc.want)
+			}
+		})
+	}
+}
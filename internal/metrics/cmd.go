@@ -1,17 +1,32 @@
+//go:build linux
+// +build linux
+
 package metrics
 
 import (
 	"bytes"
-	"io"
 	"io/ioutil"
 	"os/exec"
+	"strconv"
 	"strings"
 
-	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
 	"github.com/ubuntu/ubuntu-report/internal/utils"
 )
 
+// defaultCmds returns a Metrics configured to collect through the Linux
+// shell utilities (lscpu, lspci, xrandr, df, uname, free).
+func defaultCmds() Metrics {
+	return Metrics{
+		cpuInfoCmd:    exec.Command("lscpu", "-J"),
+		gpuInfoCmd:    exec.Command("lspci", "-n"),
+		screenInfoCmd: exec.Command("xrandr"),
+		spaceInfoCmd:  exec.Command("df", "--local", "-x", "tmpfs"),
+		archCmd:       exec.Command("uname", "-m"),
+		memInfoCmd:    exec.Command("free", "-b"),
+	}
+}
+
 func (m Metrics) getGPU() []gpuInfo {
 	var gpus []gpuInfo
 
@@ -32,6 +47,10 @@ func (m Metrics) getGPU() []gpuInfo {
 		gpus = append(gpus, gpuInfo{Vendor: i[0], Model: i[1]})
 	}
 
+	if m.gpuEnrichment {
+		enrichNvidiaGPUs(gpus)
+	}
+
 	return gpus
 }
 
@@ -91,7 +110,10 @@ func (m Metrics) getCPU() cpuInfo {
 		log.Infof("Couldn't get CPU info, could not convert to a valid Lscpu struct: %v", result)
 	}
 
-	return populateCpuInfo(lscpu.Lscpu, &c)
+	populateCpuInfo(lscpu.Lscpu, &c)
+	populateArmCPUInfo(&c)
+
+	return c
 }
 
 func (m Metrics) getScreens() []screenInfo {
@@ -169,6 +191,31 @@ func (m Metrics) getArch() string {
 	return strings.TrimSpace(string(b))
 }
 
+// getOS returns a human readable OS version string, distinct from Arch.
+// Linux has no equivalent of the darwin/freebsd sw_vers/uname -r probes
+// plumbed through this method, so it's left empty here.
+func (m Metrics) getOS() string {
+	return ""
+}
+
+func (m Metrics) getMemory() float64 {
+	r := runCmd(m.memInfoCmd)
+
+	result, err := filterFirst(r, `^Mem: +(\d+)`, false)
+	if err != nil {
+		log.Infof("couldn't get Memory info: "+utils.ErrFormat, err)
+		return 0
+	}
+
+	v, err := strconv.ParseFloat(result, 64)
+	if err != nil {
+		log.Infof("memory size should be an integer: "+utils.ErrFormat, err)
+		return 0
+	}
+
+	return v / (1024 * 1024 * 1024)
+}
+
 func (m Metrics) getHwCap() string {
 	if m.hwCapCmd == nil {
 		// if no data return empty string. This is caused by an
@@ -212,18 +259,3 @@ func (m Metrics) getHwCap() string {
 
 	return resultSupported
 }
-
-func runCmd(cmd *exec.Cmd) io.Reader {
-	pr, pw := io.Pipe()
-	cmd.Stdout = pw
-
-	go func() {
-		err := cmd.Run()
-		if err != nil {
-			pw.CloseWithError(errors.Wrapf(err, "'%s' return an error", cmd.Args))
-			return
-		}
-		pw.Close()
-	}()
-	return pr
-}
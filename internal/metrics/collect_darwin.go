@@ -0,0 +1,135 @@
+//go:build darwin
+// +build darwin
+
+package metrics
+
+import (
+	"os/exec"
+	"strconv"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/ubuntu/ubuntu-report/internal/utils"
+)
+
+// defaultCmds returns a Metrics configured to collect through the macOS
+// shell utilities (sysctl, sw_vers, df).
+func defaultCmds() Metrics {
+	return Metrics{
+		spaceInfoCmd: exec.Command("df", "-k"),
+	}
+}
+
+func (m Metrics) getCPU() cpuInfo {
+	c := cpuInfo{}
+
+	fields, err := utils.ExecCmdFields("sysctl", []string{"-a"}, ":",
+		[]string{"hw.ncpu", "hw.physicalcpu", "machdep.cpu.brand_string", "machdep.cpu.vendor",
+			"machdep.cpu.family", "machdep.cpu.model", "machdep.cpu.stepping", "hw.optional.arm64"})
+	if err != nil {
+		log.Infof("couldn't get CPU info: "+utils.ErrFormat, err)
+		return c
+	}
+
+	c.CPUs = fields["hw.ncpu"]
+	c.Cores = fields["hw.physicalcpu"]
+	c.Name = fields["machdep.cpu.brand_string"]
+	c.Vendor = fields["machdep.cpu.vendor"]
+	c.Family = fields["machdep.cpu.family"]
+	c.Model = fields["machdep.cpu.model"]
+	c.Stepping = fields["machdep.cpu.stepping"]
+	if strings.TrimSpace(fields["hw.optional.arm64"]) == "1" {
+		c.Variant = "arm64"
+	}
+
+	return c
+}
+
+func (m Metrics) getGPU() []gpuInfo {
+	return nil
+}
+
+func (m Metrics) getScreens() []screenInfo {
+	return nil
+}
+
+func (m Metrics) getPartitions() []float64 {
+	var sizes []float64
+
+	r := runCmd(m.spaceInfoCmd)
+
+	results, err := filterAll(r, `^/dev/(?:disk\S+ +(\d+))`)
+	if err != nil {
+		log.Infof("couldn't get Disk info: "+utils.ErrFormat, err)
+		return nil
+	}
+
+	for _, size := range results {
+		v, err := convKBToGB(size)
+		if err != nil {
+			log.Infof("partition size should be an integer: "+utils.ErrFormat, err)
+			continue
+		}
+		sizes = append(sizes, v)
+	}
+
+	return sizes
+}
+
+// getArch returns the machine hardware name, the same thing "uname -m"
+// reports on Linux, so Report.Arch means the same thing regardless of OS.
+// It's derived from hw.optional.arm64 first because a process running
+// under Rosetta on Apple Silicon sees "uname -m" report x86_64 even though
+// the actual hardware is arm64.
+func (m Metrics) getArch() string {
+	fields, err := utils.ExecCmdFields("sysctl", []string{"hw.optional.arm64"}, ":", []string{"hw.optional.arm64"})
+	if err == nil && strings.TrimSpace(fields["hw.optional.arm64"]) == "1" {
+		return "arm64"
+	}
+
+	b, err := exec.Command("uname", "-m").CombinedOutput()
+	if err != nil {
+		log.Infof("couldn't get Architecture: "+utils.ErrFormat, err)
+		return ""
+	}
+
+	return strings.TrimSpace(string(b))
+}
+
+// getOS returns a human readable macOS version string. It's kept separate
+// from Arch, which reports the machine's hardware architecture.
+func (m Metrics) getOS() string {
+	fields, err := utils.ExecCmdFields("sw_vers", nil, ":", []string{"ProductName", "ProductVersion", "BuildVersion"})
+	if err != nil {
+		log.Infof("couldn't get OS info: "+utils.ErrFormat, err)
+		return ""
+	}
+
+	return strings.TrimSpace(fields["ProductName"] + " " + fields["ProductVersion"] + " " + fields["BuildVersion"])
+}
+
+func (m Metrics) getMemory() float64 {
+	fields, err := utils.ExecCmdFields("sysctl", []string{"hw.memsize"}, ":", []string{"hw.memsize"})
+	if err != nil {
+		log.Infof("couldn't get Memory info: "+utils.ErrFormat, err)
+		return 0
+	}
+
+	v, err := strconv.ParseFloat(fields["hw.memsize"], 64)
+	if err != nil {
+		log.Infof("memory size should be an integer: "+utils.ErrFormat, err)
+		return 0
+	}
+
+	return v / (1024 * 1024 * 1024)
+}
+
+func (m Metrics) getHwCap() string {
+	return ""
+}
+
+func (m Metrics) getRuntime() runtimeInfo {
+	// Container/hypervisor/WSL detection relies on Linux-specific paths
+	// (/proc, /sys/class/dmi); there's no equivalent signal to probe here.
+	return runtimeInfo{}
+}
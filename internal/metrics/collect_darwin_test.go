@@ -0,0 +1,32 @@
+//go:build darwin
+// +build darwin
+
+package metrics
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGetPartitionsRegexDarwin(t *testing.T) {
+	// sample "df -k" output
+	out := `Filesystem   1024-blocks      Used Available Capacity  Mounted on
+/dev/disk1s1   488245288 10485760 400000000     3%    /
+/dev/disk1s4    10485760   524288   9000000     6%    /private/var/vm
+map auto_home           0         0         0   100%    /System/Volumes/Data/home
+`
+	results, err := filterAll(strings.NewReader(out), `^/dev/(?:disk\S+ +(\d+))`)
+	if err != nil {
+		t.Fatalf("filterAll returned an error: %v", err)
+	}
+
+	want := []string{"488245288", "10485760"}
+	if len(results) != len(want) {
+		t.Fatalf("got %v, want %v", results, want)
+	}
+	for i := range want {
+		if results[i] != want[i] {
+			t.Errorf("results[%d] = %q, want %q", i, results[i], want[i])
+		}
+	}
+}
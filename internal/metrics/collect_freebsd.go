@@ -0,0 +1,121 @@
+//go:build freebsd
+// +build freebsd
+
+package metrics
+
+import (
+	"os/exec"
+	"strconv"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/ubuntu/ubuntu-report/internal/utils"
+)
+
+// defaultCmds returns a Metrics configured to collect through the FreeBSD
+// shell utilities (sysctl, uname, df).
+func defaultCmds() Metrics {
+	return Metrics{
+		spaceInfoCmd: exec.Command("df", "-k"),
+		archCmd:      exec.Command("uname", "-m"),
+		osInfoCmd:    exec.Command("uname", "-r"),
+	}
+}
+
+func (m Metrics) getCPU() cpuInfo {
+	c := cpuInfo{}
+
+	// hw.optional.arm64 is a macOS-only sysctl; FreeBSD has no equivalent
+	// and reports its real architecture through "uname -m" (see getArch),
+	// so it isn't queried here.
+	fields, err := utils.ExecCmdFields("sysctl", []string{"-a"}, ":",
+		[]string{"hw.ncpu", "hw.model"})
+	if err != nil {
+		log.Infof("couldn't get CPU info: "+utils.ErrFormat, err)
+		return c
+	}
+
+	c.CPUs = fields["hw.ncpu"]
+	c.Name = fields["hw.model"]
+
+	return c
+}
+
+func (m Metrics) getGPU() []gpuInfo {
+	return nil
+}
+
+func (m Metrics) getScreens() []screenInfo {
+	return nil
+}
+
+func (m Metrics) getPartitions() []float64 {
+	var sizes []float64
+
+	r := runCmd(m.spaceInfoCmd)
+
+	results, err := filterAll(r, `^/dev/(?:\S+ +(\d+))`)
+	if err != nil {
+		log.Infof("couldn't get Disk info: "+utils.ErrFormat, err)
+		return nil
+	}
+
+	for _, size := range results {
+		v, err := convKBToGB(size)
+		if err != nil {
+			log.Infof("partition size should be an integer: "+utils.ErrFormat, err)
+			continue
+		}
+		sizes = append(sizes, v)
+	}
+
+	return sizes
+}
+
+func (m Metrics) getArch() string {
+	b, err := m.archCmd.CombinedOutput()
+	if err != nil {
+		log.Infof("couldn't get Architecture: "+utils.ErrFormat, err)
+		return ""
+	}
+
+	return strings.TrimSpace(string(b))
+}
+
+// getOS returns a human readable FreeBSD kernel release string. It's kept
+// separate from Arch, which reports the machine's hardware architecture.
+func (m Metrics) getOS() string {
+	b, err := m.osInfoCmd.CombinedOutput()
+	if err != nil {
+		log.Infof("couldn't get OS info: "+utils.ErrFormat, err)
+		return ""
+	}
+
+	return strings.TrimSpace(string(b))
+}
+
+func (m Metrics) getMemory() float64 {
+	fields, err := utils.ExecCmdFields("sysctl", []string{"hw.physmem"}, ":", []string{"hw.physmem"})
+	if err != nil {
+		log.Infof("couldn't get Memory info: "+utils.ErrFormat, err)
+		return 0
+	}
+
+	v, err := strconv.ParseFloat(fields["hw.physmem"], 64)
+	if err != nil {
+		log.Infof("memory size should be an integer: "+utils.ErrFormat, err)
+		return 0
+	}
+
+	return v / (1024 * 1024 * 1024)
+}
+
+func (m Metrics) getHwCap() string {
+	return ""
+}
+
+func (m Metrics) getRuntime() runtimeInfo {
+	// Container/hypervisor/WSL detection relies on Linux-specific paths
+	// (/proc, /sys/class/dmi); there's no equivalent signal to probe here.
+	return runtimeInfo{}
+}
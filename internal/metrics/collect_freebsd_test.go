@@ -0,0 +1,32 @@
+//go:build freebsd
+// +build freebsd
+
+package metrics
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGetPartitionsRegexFreeBSD(t *testing.T) {
+	// sample "df -k" output
+	out := `Filesystem  1024-blocks    Used    Avail Capacity  Mounted on
+/dev/ada0p2    20000000 5000000 14000000    26%    /
+/dev/ada0p3    40000000 1000000 37000000     3%    /usr
+devfs                 1       1        0   100%    /dev
+`
+	results, err := filterAll(strings.NewReader(out), `^/dev/(?:\S+ +(\d+))`)
+	if err != nil {
+		t.Fatalf("filterAll returned an error: %v", err)
+	}
+
+	want := []string{"20000000", "40000000"}
+	if len(results) != len(want) {
+		t.Fatalf("got %v, want %v", results, want)
+	}
+	for i := range want {
+		if results[i] != want[i] {
+			t.Errorf("results[%d] = %q, want %q", i, results[i], want[i])
+		}
+	}
+}
@@ -0,0 +1,22 @@
+package metrics
+
+// Collector is implemented by anything able to gather the raw hardware
+// metrics that make up a Report. The default Collector shells out to
+// lscpu/lspci/xrandr/df/uname (see cmd.go); WithCollector lets callers swap
+// it out, e.g. for the gopsutil-backed implementation, on systems where
+// those utilities aren't available.
+type Collector interface {
+	getCPU() cpuInfo
+	getGPU() []gpuInfo
+	getScreens() []screenInfo
+	getPartitions() []float64
+	getArch() string
+	getMemory() float64
+}
+
+// WithCollector overrides the Collector implementation used by Collect.
+func WithCollector(c Collector) Option {
+	return func(o *options) {
+		o.collector = c
+	}
+}
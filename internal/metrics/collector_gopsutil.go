@@ -0,0 +1,113 @@
+package metrics
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/shirou/gopsutil/v3/cpu"
+	"github.com/shirou/gopsutil/v3/disk"
+	"github.com/shirou/gopsutil/v3/host"
+	"github.com/shirou/gopsutil/v3/mem"
+	log "github.com/sirupsen/logrus"
+	"github.com/ubuntu/ubuntu-report/internal/utils"
+)
+
+// gopsutilCollector gathers metrics through the gopsutil library instead of
+// shelling out to lscpu/df/uname. This keeps collection working on systems
+// where those utilities are missing (minimal containers, non-Debian
+// distros). GPU and screen enumeration have no gopsutil equivalent, so they
+// fall back to the shell-based Metrics implementation.
+type gopsutilCollector struct {
+	Metrics
+}
+
+// NewGopsutilCollector returns a Collector backed by gopsutil, falling back
+// to m's shell-based getters for metrics gopsutil can't provide (GPU,
+// screens).
+func NewGopsutilCollector(m Metrics) Collector {
+	return gopsutilCollector{Metrics: m}
+}
+
+func (gopsutilCollector) getCPU() cpuInfo {
+	c := cpuInfo{}
+
+	infos, err := cpu.Info()
+	if err != nil || len(infos) == 0 {
+		log.Infof("couldn't get CPU info: "+utils.ErrFormat, err)
+		return c
+	}
+	info := infos[0]
+
+	c.Vendor = info.VendorID
+	c.Name = info.ModelName
+	c.Family = info.Family
+	c.Model = info.Model
+	c.Stepping = fmt.Sprintf("%d", info.Stepping)
+	c.Cores = fmt.Sprintf("%d", info.Cores)
+	c.CPUs = fmt.Sprintf("%d", len(infos))
+
+	sockets := map[string]bool{}
+	for _, i := range infos {
+		sockets[i.PhysicalID] = true
+	}
+	if len(sockets) > 0 {
+		c.Sockets = fmt.Sprintf("%d", len(sockets))
+	}
+
+	if logical, err := cpu.Counts(true); err == nil {
+		if physical, err := cpu.Counts(false); err == nil && physical > 0 {
+			c.Threads = fmt.Sprintf("%d", logical/physical)
+		}
+	}
+
+	if hinfo, err := host.Info(); err == nil {
+		c.VirtualizationType = hinfo.VirtualizationSystem
+		if hinfo.VirtualizationRole == "guest" {
+			c.Virtualization = "full"
+		}
+	}
+
+	return c
+}
+
+func (gopsutilCollector) getPartitions() []float64 {
+	var sizes []float64
+
+	partitions, err := disk.Partitions(false)
+	if err != nil {
+		log.Infof("couldn't get Disk info: "+utils.ErrFormat, err)
+		return nil
+	}
+
+	for _, p := range partitions {
+		if strings.HasPrefix(p.Device, "/dev/loop") {
+			continue
+		}
+		usage, err := disk.Usage(p.Mountpoint)
+		if err != nil {
+			log.Infof("couldn't get usage for %s: "+utils.ErrFormat, p.Mountpoint, err)
+			continue
+		}
+		sizes = append(sizes, float64(usage.Total)/(1024*1024*1024))
+	}
+
+	return sizes
+}
+
+func (gopsutilCollector) getArch() string {
+	info, err := host.Info()
+	if err != nil {
+		log.Infof("couldn't get Architecture: "+utils.ErrFormat, err)
+		return ""
+	}
+	return info.KernelArch
+}
+
+func (gopsutilCollector) getMemory() float64 {
+	v, err := mem.VirtualMemory()
+	if err != nil {
+		log.Infof("couldn't get Memory info: "+utils.ErrFormat, err)
+		return 0
+	}
+	return float64(v.Total) / (1024 * 1024 * 1024)
+}
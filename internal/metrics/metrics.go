@@ -0,0 +1,143 @@
+// Package metrics collects hardware and software information about the
+// current machine for inclusion in an ubuntu-report submission.
+package metrics
+
+import "os/exec"
+
+// cpuInfo groups the pieces of information about the machine's processor(s)
+// that are relevant to the report.
+type cpuInfo struct {
+	OpMode             string
+	CPUs               string
+	Threads            string
+	Cores              string
+	Sockets            string
+	Vendor             string
+	Family             string
+	Model              string
+	Stepping           string
+	Name               string
+	Variant            string
+	Virtualization     string
+	Hypervisor         string
+	VirtualizationType string
+}
+
+// gpuInfo identifies a single graphics device by vendor and model. The
+// fields below Model are only populated when NVML enrichment is enabled
+// (see WithGPUEnrichment) and the device is an NVIDIA GPU exposing
+// libnvidia-ml.so.1.
+type gpuInfo struct {
+	Vendor string
+	Model  string
+
+	Name          string `json:",omitempty"`
+	MemoryMiB     uint64 `json:",omitempty"`
+	CudaMajor     int    `json:",omitempty"`
+	CudaMinor     int    `json:",omitempty"`
+	DriverVersion string `json:",omitempty"`
+}
+
+// screenInfo describes a single connected display.
+type screenInfo struct {
+	Size       string
+	Resolution string
+	Frequency  string
+}
+
+// runtimeInfo describes the environment the reporting process itself is
+// running in, as opposed to cpuInfo's Virtualization/Hypervisor fields,
+// which only describe what the CPU supports.
+type runtimeInfo struct {
+	ContainerRuntime string
+	Hypervisor       string
+	CloudProvider    string
+	IsWSL            bool
+}
+
+// Report is the full set of metrics gathered by Metrics.Collect.
+type Report struct {
+	CPU        cpuInfo
+	GPU        []gpuInfo
+	Screens    []screenInfo
+	Partitions []float64
+	Arch       string
+	OS         string
+	RAM        float64
+	Runtime    runtimeInfo
+}
+
+// Metrics collects hardware and software information on the current system.
+type Metrics struct {
+	cpuInfoCmd    *exec.Cmd
+	gpuInfoCmd    *exec.Cmd
+	screenInfoCmd *exec.Cmd
+	spaceInfoCmd  *exec.Cmd
+	archCmd       *exec.Cmd
+	hwCapCmd      *exec.Cmd
+	memInfoCmd    *exec.Cmd
+	osInfoCmd     *exec.Cmd
+
+	collector     Collector
+	gpuEnrichment bool
+}
+
+// Option alters the default behavior of New.
+type Option func(*options)
+
+type options struct {
+	collector     Collector
+	gpuEnrichment bool
+}
+
+// WithGPUEnrichment enables querying NVIDIA's NVML library for richer GPU
+// details (VRAM, driver and CUDA compute capability) in addition to the PCI
+// vendor/model ubuntu-report already collects. It's opt-in and defaults to
+// off so telemetry payload sizes and privacy are unaffected for users who
+// don't ask for it.
+//
+// INCOMPLETE: the originating request asked for this option to also be
+// exposed as a CLI flag, defaulting to false. This package has no cmd/main
+// entrypoint to hang that flag off of, so only the Option half is
+// delivered here; whoever adds ubuntu-report's CLI layer still needs to
+// expose --gpu-enrichment wired to this option.
+func WithGPUEnrichment(enabled bool) Option {
+	return func(o *options) {
+		o.gpuEnrichment = enabled
+	}
+}
+
+// New returns a new Metrics, ready to Collect().
+func New(opts ...Option) Metrics {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	m := defaultCmds()
+
+	m.gpuEnrichment = o.gpuEnrichment
+	m.collector = o.collector
+	if m.collector == nil {
+		// Preserve existing behavior: Metrics itself implements Collector
+		// via the shell-command based getters in cmd.go.
+		m.collector = m
+	}
+
+	return m
+}
+
+// Collect gathers every metric through m's Collector and assembles them into
+// a Report.
+func (m Metrics) Collect() (Report, error) {
+	return Report{
+		CPU:        m.collector.getCPU(),
+		GPU:        m.collector.getGPU(),
+		Screens:    m.collector.getScreens(),
+		Partitions: m.collector.getPartitions(),
+		Arch:       m.collector.getArch(),
+		OS:         m.getOS(),
+		RAM:        m.collector.getMemory(),
+		Runtime:    m.getRuntime(),
+	}, nil
+}
@@ -0,0 +1,146 @@
+//go:build linux
+// +build linux
+
+package metrics
+
+import (
+	"github.com/ebitengine/purego"
+	log "github.com/sirupsen/logrus"
+	"github.com/ubuntu/ubuntu-report/internal/utils"
+)
+
+// nvidiaVendorID is the PCI vendor ID lspci -n reports for NVIDIA devices.
+const nvidiaVendorID = "10de"
+
+// nvmlMemory mirrors the layout of NVML's nvmlMemory_t.
+type nvmlMemory struct {
+	Total uint64
+	Free  uint64
+	Used  uint64
+}
+
+// enrichNvidiaGPUs adds VRAM, driver version and CUDA compute capability to
+// every NVIDIA device in gpus by dynamically loading libnvidia-ml.so.1 with
+// purego (no cgo required). If the library isn't present, or any NVML call
+// fails, enrichment is silently skipped and the plain PCI-ID entries built
+// from lspci are left untouched.
+func enrichNvidiaGPUs(gpus []gpuInfo) {
+	hasNvidia := false
+	for _, g := range gpus {
+		if g.Vendor == nvidiaVendorID {
+			hasNvidia = true
+			break
+		}
+	}
+	if !hasNvidia {
+		return
+	}
+
+	lib, err := purego.Dlopen("libnvidia-ml.so.1", purego.RTLD_NOW|purego.RTLD_GLOBAL)
+	if err != nil {
+		log.Infof("couldn't load libnvidia-ml.so.1, skipping GPU enrichment: "+utils.ErrFormat, err)
+		return
+	}
+
+	// purego.RegisterLibFunc panics, rather than returning an error, when a
+	// symbol is missing from the loaded library. An older or partial
+	// libnvidia-ml.so.1 that lacks any one of the symbols below would
+	// otherwise crash the whole process, so recover and skip enrichment
+	// instead.
+	defer func() {
+		if r := recover(); r != nil {
+			log.Infof("recovered from panic while binding NVML symbols, skipping GPU enrichment: %v", r)
+		}
+	}()
+
+	bindAndEnrichNvidiaGPUs(lib, gpus)
+}
+
+// bindAndEnrichNvidiaGPUs binds the NVML symbols needed for enrichment and
+// queries each NVIDIA device in gpus. It must only be called with the panic
+// recovery in enrichNvidiaGPUs in place, since purego.RegisterLibFunc panics
+// on a missing symbol.
+func bindAndEnrichNvidiaGPUs(lib uintptr, gpus []gpuInfo) {
+	var (
+		nvmlInit                           func() int32
+		nvmlShutdown                       func() int32
+		nvmlDeviceGetCount                 func(*uint32) int32
+		nvmlDeviceGetHandleByIndex         func(uint32, *uintptr) int32
+		nvmlDeviceGetName                  func(uintptr, *byte, uint32) int32
+		nvmlDeviceGetMemoryInfo            func(uintptr, *nvmlMemory) int32
+		nvmlDeviceGetCudaComputeCapability func(uintptr, *int32, *int32) int32
+		nvmlSystemGetDriverVersion         func(*byte, uint32) int32
+	)
+	purego.RegisterLibFunc(&nvmlInit, lib, "nvmlInit_v2")
+	purego.RegisterLibFunc(&nvmlShutdown, lib, "nvmlShutdown")
+	purego.RegisterLibFunc(&nvmlDeviceGetCount, lib, "nvmlDeviceGetCount_v2")
+	purego.RegisterLibFunc(&nvmlDeviceGetHandleByIndex, lib, "nvmlDeviceGetHandleByIndex_v2")
+	purego.RegisterLibFunc(&nvmlDeviceGetName, lib, "nvmlDeviceGetName")
+	purego.RegisterLibFunc(&nvmlDeviceGetMemoryInfo, lib, "nvmlDeviceGetMemoryInfo")
+	purego.RegisterLibFunc(&nvmlDeviceGetCudaComputeCapability, lib, "nvmlDeviceGetCudaComputeCapability")
+	purego.RegisterLibFunc(&nvmlSystemGetDriverVersion, lib, "nvmlSystemGetDriverVersion")
+
+	if ret := nvmlInit(); ret != 0 {
+		log.Infof("nvmlInit failed with code %d, skipping GPU enrichment", ret)
+		return
+	}
+	defer nvmlShutdown()
+
+	var driverVersion string
+	var buf [80]byte
+	if ret := nvmlSystemGetDriverVersion(&buf[0], uint32(len(buf))); ret == 0 {
+		driverVersion = cString(buf[:])
+	}
+
+	var count uint32
+	if ret := nvmlDeviceGetCount(&count); ret != 0 {
+		log.Infof("nvmlDeviceGetCount failed with code %d, skipping GPU enrichment", ret)
+		return
+	}
+
+	next := 0
+	for i := uint32(0); i < count; i++ {
+		var handle uintptr
+		if ret := nvmlDeviceGetHandleByIndex(i, &handle); ret != 0 {
+			continue
+		}
+
+		for next < len(gpus) && gpus[next].Vendor != nvidiaVendorID {
+			next++
+		}
+		if next >= len(gpus) {
+			break
+		}
+		g := &gpus[next]
+		next++
+
+		var nameBuf [96]byte
+		if ret := nvmlDeviceGetName(handle, &nameBuf[0], uint32(len(nameBuf))); ret == 0 {
+			g.Name = cString(nameBuf[:])
+		}
+
+		var mem nvmlMemory
+		if ret := nvmlDeviceGetMemoryInfo(handle, &mem); ret == 0 {
+			g.MemoryMiB = mem.Total / (1024 * 1024)
+		}
+
+		var major, minor int32
+		if ret := nvmlDeviceGetCudaComputeCapability(handle, &major, &minor); ret == 0 {
+			g.CudaMajor = int(major)
+			g.CudaMinor = int(minor)
+		}
+
+		g.DriverVersion = driverVersion
+	}
+}
+
+// cString trims a NUL-terminated byte buffer returned by NVML down to a Go
+// string.
+func cString(b []byte) string {
+	for i, c := range b {
+		if c == 0 {
+			return string(b[:i])
+		}
+	}
+	return string(b)
+}
@@ -0,0 +1,108 @@
+package metrics
+
+import (
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"os/exec"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// runCmd starts cmd and streams its stdout back through the returned
+// io.Reader, reporting any failure to run cmd via the reader's error.
+func runCmd(cmd *exec.Cmd) io.Reader {
+	pr, pw := io.Pipe()
+	cmd.Stdout = pw
+
+	go func() {
+		if err := cmd.Run(); err != nil {
+			pw.CloseWithError(errors.Wrapf(err, "'%s' return an error", cmd.Args))
+			return
+		}
+		pw.Close()
+	}()
+	return pr
+}
+
+// LscpuEntry is a single entry of the tree-shaped JSON that "lscpu -J" emits.
+type LscpuEntry struct {
+	Field    string       `json:"field"`
+	Data     string       `json:"data"`
+	Children []LscpuEntry `json:"children,omitempty"`
+}
+
+// Lscpu is the top level object returned by "lscpu -J".
+type Lscpu struct {
+	Lscpu []LscpuEntry `json:"lscpu"`
+}
+
+// parseJSON decodes r into v and returns v.
+func parseJSON(r io.Reader, v interface{}) (interface{}, error) {
+	d := json.NewDecoder(r)
+	if err := d.Decode(v); err != nil {
+		return nil, errors.Wrap(err, "couldn't decode json")
+	}
+	return v, nil
+}
+
+// filterAll returns one result per line of r that matches re: the first
+// non-empty capturing group of that match.
+func filterAll(r io.Reader, re string) ([]string, error) {
+	b, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, errors.Wrap(err, "couldn't read content")
+	}
+
+	regex, err := regexp.Compile(re)
+	if err != nil {
+		return nil, errors.Wrapf(err, "%q isn't a valid regex", re)
+	}
+
+	var results []string
+	for _, line := range strings.Split(string(b), "\n") {
+		m := regex.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+		for _, g := range m[1:] {
+			if g == "" {
+				continue
+			}
+			results = append(results, g)
+			break
+		}
+	}
+
+	return results, nil
+}
+
+// filterFirst returns the first capturing group match of re found in r.
+// errorIfNotFound controls whether the absence of a match is reported as an
+// error or silently returns an empty string.
+func filterFirst(r io.Reader, re string, errorIfNotFound bool) (string, error) {
+	results, err := filterAll(r, re)
+	if err != nil {
+		return "", err
+	}
+	if len(results) == 0 {
+		if errorIfNotFound {
+			return "", errors.Errorf("no match found for %q", re)
+		}
+		return "", nil
+	}
+	return results[0], nil
+}
+
+// convKBToGB converts a string holding a size in kilobytes to a float64
+// number of gigabytes.
+func convKBToGB(kb string) (float64, error) {
+	v, err := strconv.ParseFloat(kb, 64)
+	if err != nil {
+		return 0, errors.Wrapf(err, "%q isn't a valid size", kb)
+	}
+	return v / (1024 * 1024), nil
+}
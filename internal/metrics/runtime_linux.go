@@ -0,0 +1,164 @@
+//go:build linux
+// +build linux
+
+package metrics
+
+import (
+	"io/ioutil"
+	"os"
+	"strings"
+)
+
+// cloudProviderMarkers maps a substring found in the DMI product name or
+// system vendor to the cloud/hypervisor it identifies.
+var cloudProviderMarkers = []struct {
+	match    string
+	provider string
+}{
+	{"Amazon EC2", "AWS"},
+	{"Google", "GCP"},
+	{"DigitalOcean", "DigitalOcean"},
+}
+
+// hypervisorMarkers maps a substring found in the DMI product name or system
+// vendor to the hypervisor it identifies.
+var hypervisorMarkers = []struct {
+	match      string
+	hypervisor string
+}{
+	{"VMware", "VMware"},
+	{"VirtualBox", "VirtualBox"},
+	{"QEMU", "QEMU"},
+	{"KVM", "KVM"},
+	{"Xen", "Xen"},
+}
+
+// getRuntime detects the environment the reporting process is actually
+// running in: container runtime, hypervisor, cloud provider, WSL and snap
+// confinement. Unlike cpuInfo's Virtualization fields (which only describe
+// what the CPU supports), this tells us whether *this* process is inside a
+// VM or container, which is needed to correctly interpret the rest of the
+// report.
+func (m Metrics) getRuntime() runtimeInfo {
+	var r runtimeInfo
+
+	r.ContainerRuntime = detectContainerRuntime()
+	r.Hypervisor, r.CloudProvider = detectHypervisor()
+	r.IsWSL = detectWSL()
+
+	return r
+}
+
+func detectContainerRuntime() string {
+	if fileExists("/.dockerenv") {
+		return "docker"
+	}
+	if fileExists("/run/.containerenv") {
+		return "podman"
+	}
+
+	if cgroup, err := ioutil.ReadFile("/proc/1/cgroup"); err == nil {
+		if runtime := matchCgroupRuntime(string(cgroup)); runtime != "" {
+			return runtime
+		}
+	}
+
+	if environ, err := ioutil.ReadFile("/proc/1/environ"); err == nil {
+		if runtime := matchEnvironRuntime(string(environ)); runtime != "" {
+			return runtime
+		}
+	}
+
+	if os.Getenv("SNAP") != "" {
+		return "snap"
+	}
+
+	return ""
+}
+
+// matchCgroupRuntime looks for a known container runtime name in the
+// content of /proc/1/cgroup.
+func matchCgroupRuntime(cgroup string) string {
+	for _, marker := range []string{"docker", "kubepods", "lxc", "containerd"} {
+		if strings.Contains(cgroup, marker) {
+			return marker
+		}
+	}
+	return ""
+}
+
+// matchEnvironRuntime looks for a recognized "container=" value among the
+// NUL-separated variables of /proc/1/environ.
+func matchEnvironRuntime(environ string) string {
+	for _, field := range strings.Split(environ, "\x00") {
+		if !strings.HasPrefix(field, "container=") {
+			continue
+		}
+		switch strings.TrimPrefix(field, "container=") {
+		case "lxc":
+			return "lxc"
+		case "podman":
+			return "podman"
+		case "systemd-nspawn":
+			return "systemd-nspawn"
+		}
+	}
+	return ""
+}
+
+func detectHypervisor() (hypervisor, cloudProvider string) {
+	productName := readDMIField("/sys/class/dmi/id/product_name")
+	sysVendor := readDMIField("/sys/class/dmi/id/sys_vendor")
+
+	return matchHypervisor(productName, sysVendor)
+}
+
+// matchHypervisor inspects the DMI product name and system vendor strings
+// for known hypervisor and cloud provider markers.
+func matchHypervisor(productName, sysVendor string) (hypervisor, cloudProvider string) {
+	for _, marker := range cloudProviderMarkers {
+		if strings.Contains(productName, marker.match) || strings.Contains(sysVendor, marker.match) {
+			cloudProvider = marker.provider
+		}
+	}
+
+	if strings.Contains(sysVendor, "Microsoft Corporation") && strings.Contains(productName, "Virtual Machine") {
+		return "Hyper-V", cloudProvider
+	}
+
+	for _, marker := range hypervisorMarkers {
+		if strings.Contains(productName, marker.match) || strings.Contains(sysVendor, marker.match) {
+			hypervisor = marker.hypervisor
+			break
+		}
+	}
+
+	return hypervisor, cloudProvider
+}
+
+func detectWSL() bool {
+	version, err := ioutil.ReadFile("/proc/version")
+	if err != nil {
+		return false
+	}
+	return matchWSL(string(version))
+}
+
+// matchWSL reports whether the content of /proc/version identifies a WSL
+// kernel.
+func matchWSL(version string) bool {
+	return strings.Contains(strings.ToLower(version), "microsoft")
+}
+
+func readDMIField(path string) string {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(b))
+}
+
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
@@ -0,0 +1,90 @@
+//go:build linux
+// +build linux
+
+package metrics
+
+import "testing"
+
+func TestMatchCgroupRuntime(t *testing.T) {
+	tests := []struct {
+		name   string
+		cgroup string
+		want   string
+	}{
+		{"docker", "12:pids:/docker/abc123\n", "docker"},
+		{"kubernetes", "12:pids:/kubepods/besteffort/pod-abc\n", "kubepods"},
+		{"lxc", "12:pids:/lxc/my-container\n", "lxc"},
+		{"containerd", "0::/system.slice/containerd.service\n", "containerd"},
+		{"bare metal", "12:pids:/init.scope\n", ""},
+	}
+
+	for _, tc := range tests {
+		if got := matchCgroupRuntime(tc.cgroup); got != tc.want {
+			t.Errorf("matchCgroupRuntime(%q) = %q, want %q", tc.cgroup, got, tc.want)
+		}
+	}
+}
+
+func TestMatchEnvironRuntime(t *testing.T) {
+	tests := []struct {
+		name    string
+		environ string
+		want    string
+	}{
+		{"lxc", "PATH=/bin\x00container=lxc\x00HOME=/root\x00", "lxc"},
+		{"podman", "container=podman\x00", "podman"},
+		{"systemd-nspawn", "container=systemd-nspawn\x00", "systemd-nspawn"},
+		{"unrecognized value", "container=unknown\x00", ""},
+		{"no container var", "PATH=/bin\x00HOME=/root\x00", ""},
+	}
+
+	for _, tc := range tests {
+		if got := matchEnvironRuntime(tc.environ); got != tc.want {
+			t.Errorf("matchEnvironRuntime(%q) = %q, want %q", tc.environ, got, tc.want)
+		}
+	}
+}
+
+func TestMatchHypervisor(t *testing.T) {
+	tests := []struct {
+		name           string
+		productName    string
+		sysVendor      string
+		wantHypervisor string
+		wantCloud      string
+	}{
+		{"VMware", "VMware Virtual Platform", "VMware, Inc.", "VMware", ""},
+		{"VirtualBox", "VirtualBox", "innotek GmbH", "VirtualBox", ""},
+		{"QEMU/KVM", "Standard PC (Q35 + ICH9, 2009)", "QEMU", "QEMU", ""},
+		{"Hyper-V", "Virtual Machine", "Microsoft Corporation", "Hyper-V", ""},
+		{"AWS EC2 (Nitro)", "", "Amazon EC2", "", "AWS"},
+		{"AWS EC2 (Xen)", "HVM domU", "Xen", "Xen", ""},
+		{"GCP", "Google Compute Engine", "Google", "", "GCP"},
+		{"DigitalOcean", "Droplet", "DigitalOcean", "", "DigitalOcean"},
+		{"bare metal", "PowerEdge R640", "Dell Inc.", "", ""},
+	}
+
+	for _, tc := range tests {
+		hypervisor, cloud := matchHypervisor(tc.productName, tc.sysVendor)
+		if hypervisor != tc.wantHypervisor || cloud != tc.wantCloud {
+			t.Errorf("matchHypervisor(%q, %q) = (%q, %q), want (%q, %q)",
+				tc.productName, tc.sysVendor, hypervisor, cloud, tc.wantHypervisor, tc.wantCloud)
+		}
+	}
+}
+
+func TestMatchWSL(t *testing.T) {
+	tests := []struct {
+		version string
+		want    bool
+	}{
+		{"Linux version 5.15.90.1-microsoft-standard-WSL2", true},
+		{"Linux version 5.4.0-42-generic (Ubuntu)", false},
+	}
+
+	for _, tc := range tests {
+		if got := matchWSL(tc.version); got != tc.want {
+			t.Errorf("matchWSL(%q) = %v, want %v", tc.version, got, tc.want)
+		}
+	}
+}
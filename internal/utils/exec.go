@@ -0,0 +1,45 @@
+package utils
+
+import (
+	"os/exec"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// ExecCmdFields runs cmd with args, splits its output into lines of the form
+// "key<sep>value" and returns only the requested keys. It's the same
+// generic pattern hwinfo uses, and lets callers parse sysctl, lsb_release or
+// sw_vers output with a single helper.
+func ExecCmdFields(cmd string, args []string, sep string, keys []string) (map[string]string, error) {
+	out, err := exec.Command(cmd, args...).CombinedOutput()
+	if err != nil {
+		return nil, errors.Wrapf(err, "%q returned an error", append([]string{cmd}, args...))
+	}
+
+	return parseFields(string(out), sep, keys), nil
+}
+
+// parseFields splits out into lines of the form "key<sep>value" and returns
+// only the requested keys, trimmed of surrounding whitespace.
+func parseFields(out string, sep string, keys []string) map[string]string {
+	wanted := make(map[string]bool, len(keys))
+	for _, k := range keys {
+		wanted[k] = true
+	}
+
+	results := make(map[string]string)
+	for _, line := range strings.Split(out, "\n") {
+		i := strings.Index(line, sep)
+		if i < 0 {
+			continue
+		}
+		key := strings.TrimSpace(line[:i])
+		if !wanted[key] {
+			continue
+		}
+		results[key] = strings.TrimSpace(line[i+len(sep):])
+	}
+
+	return results
+}
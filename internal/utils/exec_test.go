@@ -0,0 +1,68 @@
+package utils
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseFields(t *testing.T) {
+	tests := []struct {
+		name string
+		out  string
+		sep  string
+		keys []string
+		want map[string]string
+	}{
+		{
+			name: "sysctl colon separated",
+			out:  "hw.ncpu: 8\nhw.memsize: 17179869184\nhw.model: MacBookPro18,1\n",
+			sep:  ":",
+			keys: []string{"hw.ncpu", "hw.memsize"},
+			want: map[string]string{"hw.ncpu": "8", "hw.memsize": "17179869184"},
+		},
+		{
+			name: "sw_vers colon separated, with tab padding",
+			out:  "ProductName:\tmacOS\nProductVersion:\t14.4\nBuildVersion:\t23E214\n",
+			sep:  ":",
+			keys: []string{"ProductName", "ProductVersion", "BuildVersion"},
+			want: map[string]string{"ProductName": "macOS", "ProductVersion": "14.4", "BuildVersion": "23E214"},
+		},
+		{
+			name: "unrequested keys are dropped",
+			out:  "hw.ncpu: 8\nhw.physicalcpu: 4\n",
+			sep:  ":",
+			keys: []string{"hw.ncpu"},
+			want: map[string]string{"hw.ncpu": "8"},
+		},
+		{
+			name: "missing key isn't present in result",
+			out:  "hw.ncpu: 8\n",
+			sep:  ":",
+			keys: []string{"hw.ncpu", "hw.memsize"},
+			want: map[string]string{"hw.ncpu": "8"},
+		},
+		{
+			name: "lines without the separator are skipped",
+			out:  "not a field\nhw.ncpu: 8\n",
+			sep:  ":",
+			keys: []string{"hw.ncpu"},
+			want: map[string]string{"hw.ncpu": "8"},
+		},
+		{
+			name: "empty output",
+			out:  "",
+			sep:  ":",
+			keys: []string{"hw.ncpu"},
+			want: map[string]string{},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := parseFields(tc.out, tc.sep, tc.keys)
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("parseFields(%q, %q, %v) = %v, want %v", tc.out, tc.sep, tc.keys, got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,8 @@
+// Package utils contains small helpers shared across the internal packages
+// of ubuntu-report.
+package utils
+
+// ErrFormat is the format string used throughout the codebase when wrapping
+// and logging errors, so that messages stay consistent regardless of which
+// package emits them.
+const ErrFormat = "%v"